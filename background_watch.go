@@ -0,0 +1,75 @@
+package lipgloss
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// backgroundPollInterval is how often WatchBackground re-queries the
+// output's background color via OSC 11 when SIGWINCH isn't available, or
+// hasn't fired. It's a var, not a const, so tests can shrink it.
+var backgroundPollInterval = 2 * time.Second
+
+// WatchBackground watches this renderer's output for background color
+// changes and emits the new HasDarkBackground() value whenever it changes.
+// It re-queries the background on a timer and, on platforms that support
+// it, on SIGWINCH. The returned channel is closed when ctx is done.
+//
+// The OSC 11 query this relies on degrades silently on terminals that
+// don't respond to it: HasDarkBackground() just keeps returning its last
+// known value, so WatchBackground never emits.
+//
+// There is no Style.OnBackgroundChange hook yet, since this package has no
+// Style type for it to attach to; a Bubble Tea program (or anything else
+// that wants a re-render callback) should range over this channel itself
+// and trigger its own re-render on each value.
+func (r *Renderer) WatchBackground(ctx context.Context) <-chan bool {
+	ch := make(chan bool)
+
+	go func() {
+		defer close(ch)
+
+		sigCh := make(chan os.Signal, 1)
+		notifySigwinch(sigCh)
+		defer signal.Stop(sigCh)
+
+		ticker := time.NewTicker(backgroundPollInterval)
+		defer ticker.Stop()
+
+		last := r.HasDarkBackground()
+
+		check := func() bool {
+			r.InvalidateBackgroundCache()
+			current := r.HasDarkBackground()
+			if current == last {
+				return true
+			}
+			last = current
+			select {
+			case ch <- current:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !check() {
+					return
+				}
+			case <-sigCh:
+				if !check() {
+					return
+				}
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,82 @@
+package lipgloss
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatchBackgroundEmitsOnChange(t *testing.T) {
+	old := backgroundPollInterval
+	backgroundPollInterval = time.Millisecond
+	defer func() { backgroundPollInterval = old }()
+
+	r := NewRenderer(&bytes.Buffer{})
+	r.SetHasDarkBackground(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := r.WatchBackground(ctx)
+
+	// Give WatchBackground's goroutine a chance to capture its initial
+	// "last" value before we flip it, so the flip is guaranteed to be seen
+	// as a change rather than racing the goroutine's startup.
+	time.Sleep(20 * time.Millisecond)
+	r.SetHasDarkBackground(true)
+
+	select {
+	case got, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed before emitting a change")
+		}
+		if !got {
+			t.Errorf("got %v, want true", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a background change")
+	}
+}
+
+func TestWatchBackgroundClosesOnContextCancel(t *testing.T) {
+	old := backgroundPollInterval
+	backgroundPollInterval = time.Millisecond
+	defer func() { backgroundPollInterval = old }()
+
+	r := NewRenderer(&bytes.Buffer{})
+	r.SetHasDarkBackground(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := r.WatchBackground(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel to be closed, got a value instead")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
+
+func TestWatchBackgroundNoEmitWithoutChange(t *testing.T) {
+	old := backgroundPollInterval
+	backgroundPollInterval = time.Millisecond
+	defer func() { backgroundPollInterval = old }()
+
+	r := NewRenderer(&bytes.Buffer{})
+	r.SetHasDarkBackground(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := r.WatchBackground(ctx)
+
+	select {
+	case got := <-ch:
+		t.Fatalf("expected no emission without a background change, got %v", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
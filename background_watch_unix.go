@@ -0,0 +1,15 @@
+//go:build !windows
+
+package lipgloss
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySigwinch registers c to receive SIGWINCH, which most terminals
+// send on resize and some terminal emulators also send on theme change.
+func notifySigwinch(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGWINCH)
+}
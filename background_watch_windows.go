@@ -0,0 +1,9 @@
+//go:build windows
+
+package lipgloss
+
+import "os"
+
+// notifySigwinch is a no-op on Windows, which has no SIGWINCH. WatchBackground
+// still re-checks the background on its poll timer.
+func notifySigwinch(c chan<- os.Signal) {}
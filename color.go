@@ -1,40 +1,20 @@
 package lipgloss
 
 import (
-	"sync"
+	"context"
 
 	"github.com/muesli/termenv"
 )
 
-var (
-	colorProfile         termenv.Profile
-	getColorProfile      sync.Once
-	explicitColorProfile bool
-
-	hasDarkBackground       bool
-	getBackgroundColor      sync.Once
-	explicitBackgroundColor bool
-
-	colorProfileMtx sync.RWMutex
-)
-
-// ColorProfile returns the detected termenv color profile. It will perform the
-// actual check only once.
+// ColorProfile returns DefaultRenderer's color profile. It will perform the
+// actual check only once, until invalidated with InvalidateColorProfile.
 func ColorProfile() termenv.Profile {
-	colorProfileMtx.RLock()
-	defer colorProfileMtx.RUnlock()
-
-	if !explicitColorProfile {
-		getColorProfile.Do(func() {
-			colorProfile = termenv.EnvColorProfile()
-		})
-	}
-	return colorProfile
+	return DefaultRenderer().ColorProfile()
 }
 
-// SetColorProfile sets the color profile on a package-wide context. This
-// function exists mostly for testing purposes so that you can assure you're
-// testing against a specific profile.
+// SetColorProfile sets the color profile on DefaultRenderer. This function
+// exists mostly for testing purposes so that you can assure you're testing
+// against a specific profile.
 //
 // Outside of testing you likely won't want to use this function as
 // ColorProfile() will detect and cache the terminal's color capabilities
@@ -49,30 +29,18 @@ func ColorProfile() termenv.Profile {
 //
 // This function is thread-safe.
 func SetColorProfile(p termenv.Profile) {
-	colorProfileMtx.Lock()
-	defer colorProfileMtx.Unlock()
-
-	colorProfile = p
-	explicitColorProfile = true
+	DefaultRenderer().SetColorProfile(p)
 }
 
-// HasDarkBackground returns whether or not the terminal has a dark background.
+// HasDarkBackground returns whether or not DefaultRenderer's terminal has a
+// dark background.
 func HasDarkBackground() bool {
-	colorProfileMtx.RLock()
-	defer colorProfileMtx.RUnlock()
-
-	if !explicitBackgroundColor {
-		getBackgroundColor.Do(func() {
-			hasDarkBackground = termenv.HasDarkBackground()
-		})
-	}
-
-	return hasDarkBackground
+	return DefaultRenderer().HasDarkBackground()
 }
 
-// SetHasDarkBackground sets the value of the background color detection on a
-// package-wide context. This function exists mostly for testing purposes so
-// that you can assure you're testing against a specific background color
+// SetHasDarkBackground sets the value of the background color detection on
+// DefaultRenderer. This function exists mostly for testing purposes so that
+// you can assure you're testing against a specific background color
 // setting.
 //
 // Outside of testing you likely won't want to use this function as
@@ -81,18 +49,32 @@ func HasDarkBackground() bool {
 //
 // This function is thread-safe.
 func SetHasDarkBackground(b bool) {
-	colorProfileMtx.Lock()
-	defer colorProfileMtx.Unlock()
+	DefaultRenderer().SetHasDarkBackground(b)
+}
 
-	hasDarkBackground = b
-	explicitBackgroundColor = true
+// InvalidateBackgroundCache clears DefaultRenderer's cached background
+// color detection, so the next call to HasDarkBackground() re-queries it.
+func InvalidateBackgroundCache() {
+	DefaultRenderer().InvalidateBackgroundCache()
+}
+
+// InvalidateColorProfile clears DefaultRenderer's cached color profile, so
+// the next call to ColorProfile() re-detects it.
+func InvalidateColorProfile() {
+	DefaultRenderer().InvalidateColorProfile()
+}
+
+// WatchBackground watches DefaultRenderer's output for background color
+// changes. See (*Renderer).WatchBackground for details.
+func WatchBackground(ctx context.Context) <-chan bool {
+	return DefaultRenderer().WatchBackground(ctx)
 }
 
 // TerminalColor is a color intended to be rendered in the terminal. It
 // satisfies the Go color.Color interface.
 type TerminalColor interface {
 	value() string
-	color() termenv.Color
+	color(r *Renderer) termenv.Color
 	RGBA() (r, g, b, a uint32)
 }
 
@@ -109,8 +91,8 @@ func (n NoColor) value() string {
 	return ""
 }
 
-func (n NoColor) color() termenv.Color {
-	return ColorProfile().Color("")
+func (n NoColor) color(r *Renderer) termenv.Color {
+	return r.ColorProfile().Color("")
 }
 
 // RGBA returns the RGBA value of this color. Because we have to return
@@ -124,18 +106,26 @@ func (n NoColor) RGBA() (r, g, b, a uint32) {
 
 var noColor = NoColor{}
 
-// Color specifies a color by hex or ANSI value. For example:
+// Color specifies a color by hex or ANSI value, or by a "$name" reference
+// into DefaultPalette. For example:
 //
 //	ansiColor := lipgloss.Color("21")
 //	hexColor := lipgloss.Color("#0000ff")
+//	namedColor := lipgloss.Color("$primary")
 type Color string
 
 func (c Color) value() string {
+	if named, ok := resolveNamed(string(c)); ok {
+		return named.value()
+	}
 	return string(c)
 }
 
-func (c Color) color() termenv.Color {
-	return ColorProfile().Color(string(c))
+func (c Color) color(r *Renderer) termenv.Color {
+	if named, ok := resolveNamed(string(c)); ok {
+		return named.color(r)
+	}
+	return r.ColorProfile().Color(string(c))
 }
 
 // RGBA returns the RGBA value of this color. This satisfies the Go Color
@@ -143,7 +133,7 @@ func (c Color) color() termenv.Color {
 //
 // Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
 func (c Color) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(c.color()).RGBA()
+	return termenv.ConvertToRGB(c.color(DefaultRenderer())).RGBA()
 }
 
 // AdaptiveColor provides color options for light and dark backgrounds. The
@@ -165,8 +155,11 @@ func (ac AdaptiveColor) value() string {
 	return ac.Light
 }
 
-func (ac AdaptiveColor) color() termenv.Color {
-	return ColorProfile().Color(ac.value())
+func (ac AdaptiveColor) color(r *Renderer) termenv.Color {
+	if r.HasDarkBackground() {
+		return r.ColorProfile().Color(ac.Dark)
+	}
+	return r.ColorProfile().Color(ac.Light)
 }
 
 // RGBA returns the RGBA value of this color. This satisfies the Go Color
@@ -174,7 +167,7 @@ func (ac AdaptiveColor) color() termenv.Color {
 //
 // Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
 func (ac AdaptiveColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(ac.color()).RGBA()
+	return termenv.ConvertToRGB(ac.color(DefaultRenderer())).RGBA()
 }
 
 // CompleteColor specifies exact values for truecolor, ANSI256, and ANSI color
@@ -198,8 +191,17 @@ func (c CompleteColor) value() string {
 	}
 }
 
-func (c CompleteColor) color() termenv.Color {
-	return colorProfile.Color(c.value())
+func (c CompleteColor) color(r *Renderer) termenv.Color {
+	switch r.ColorProfile() {
+	case termenv.TrueColor:
+		return r.ColorProfile().Color(c.TrueColor)
+	case termenv.ANSI256:
+		return r.ColorProfile().Color(c.ANSI256)
+	case termenv.ANSI:
+		return r.ColorProfile().Color(c.ANSI)
+	default:
+		return r.ColorProfile().Color("")
+	}
 }
 
 // RGBA returns the RGBA value of this color. This satisfies the Go Color
@@ -207,7 +209,7 @@ func (c CompleteColor) color() termenv.Color {
 //
 // Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
 func (c CompleteColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(c.color()).RGBA()
+	return termenv.ConvertToRGB(c.color(DefaultRenderer())).RGBA()
 }
 
 // CompleteColor specifies exact values for truecolor, ANSI256, and ANSI color
@@ -225,8 +227,11 @@ func (cac CompleteAdaptiveColor) value() string {
 	return cac.Light.value()
 }
 
-func (cac CompleteAdaptiveColor) color() termenv.Color {
-	return ColorProfile().Color(cac.value())
+func (cac CompleteAdaptiveColor) color(r *Renderer) termenv.Color {
+	if r.HasDarkBackground() {
+		return cac.Dark.color(r)
+	}
+	return cac.Light.color(r)
 }
 
 // RGBA returns the RGBA value of this color. This satisfies the Go Color
@@ -234,39 +239,5 @@ func (cac CompleteAdaptiveColor) color() termenv.Color {
 //
 // Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
 func (cac CompleteAdaptiveColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(cac.color()).RGBA()
-}
-
-// GradientColour specifies the start and end hex values for a colour gradient.
-// The RGBA is blended based on the position/steps parameters. During render the
-// gradient will be applied to the string provided, and the Steps parameter
-// will be set to the Width() of the string provided to render.
-// Currently only right to left gradient is supported.
-//
-// TODO: Add option for multiline:
-//  - corner to corner
-//  - radial
-//  - inverse
-type GradientColour struct {
-	Start    string
-	End      string
-	Steps    int
-	Position int
-}
-
-func (gc GradientColour) value() string {
-	sc := termenv.ConvertToRGB(ColorProfile().Color(gc.Start))
-	ec := termenv.ConvertToRGB(ColorProfile().Color(gc.End))
-
-	n := sc.BlendRgb(ec, float64(gc.Position)/float64(gc.Steps))
-
-	return n.Hex()
-}
-
-func (gc GradientColour) color() termenv.Color {
-	return ColorProfile().Color(gc.value())
-}
-
-func (gc GradientColour) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(gc.color()).RGBA()
+	return termenv.ConvertToRGB(cac.color(DefaultRenderer())).RGBA()
 }
@@ -0,0 +1,210 @@
+package lipgloss
+
+import (
+	"math"
+	"sort"
+
+	"github.com/muesli/termenv"
+)
+
+// GradientDirection describes the axis a GradientColour is interpolated
+// along when it's resolved against a cell position.
+type GradientDirection int
+
+// Available gradient directions.
+const (
+	Horizontal GradientDirection = iota
+	Vertical
+	DiagonalTL_BR
+	DiagonalTR_BL
+	Radial
+	AngleDirection
+)
+
+// GradientStop is a single color stop in a GradientColour. Pos is the
+// position of the stop along the gradient, from 0.0 (the start) to 1.0 (the
+// end). Stops do not need to be pre-sorted; they're sorted by Pos each time
+// the gradient is resolved.
+type GradientStop struct {
+	Color string
+	Pos   float64
+}
+
+// renderContext describes where, within a rendered block of text, a
+// positional color is being resolved, so that colors like GradientColour can
+// compute a per-cell blend. At builds one from its arguments; nothing in this
+// package passes one through text rendering.
+type renderContext struct {
+	x, y int
+	w, h int
+}
+
+// GradientColour specifies an ordered list of color stops to blend across a
+// run of text. Direction, Inverse, and Angle describe how a cell's (x, y)
+// position within a block maps to a point along the gradient; At performs
+// that mapping and blends the two neighboring Stops to produce the cell's
+// color.
+//
+// GradientColour also satisfies TerminalColor, but as a plain TerminalColor
+// it has no cell position to resolve against: value(), color(), and RGBA()
+// all answer with the gradient's first stop (the one with the lowest Pos).
+// This package has no Style type and nothing in it passes a cell's position
+// to color(), so a GradientColour used as a TerminalColor resolves to one
+// flat color, not a gradient. Callers that want an
+// actual rendered gradient must call At themselves for each cell, the same
+// way the Start/End/Position/Steps fields it replaced had to be driven by
+// hand.
+//
+// Example usage:
+//
+//	grad := lipgloss.GradientColour{
+//		Stops: []lipgloss.GradientStop{
+//			{Color: "#f00", Pos: 0},
+//			{Color: "#0f0", Pos: 0.5},
+//			{Color: "#00f", Pos: 1},
+//		},
+//		Direction: lipgloss.Radial,
+//	}
+//	for y := 0; y < h; y++ {
+//		for x := 0; x < w; x++ {
+//			cellColor := grad.At(lipgloss.DefaultRenderer(), x, y, w, h)
+//			// ... render the cell at (x, y) using cellColor.
+//		}
+//	}
+type GradientColour struct {
+	Stops     []GradientStop
+	Direction GradientDirection
+
+	// Angle is the gradient angle in degrees, measured clockwise from the
+	// horizontal axis. It's only used when Direction is AngleDirection.
+	Angle float64
+
+	// Inverse flips the resolved position, running the gradient in the
+	// opposite direction.
+	Inverse bool
+}
+
+// sortedStops returns a copy of gc.Stops sorted by Pos.
+func (gc GradientColour) sortedStops() []GradientStop {
+	stops := make([]GradientStop, len(gc.Stops))
+	copy(stops, gc.Stops)
+	sort.Slice(stops, func(i, j int) bool {
+		return stops[i].Pos < stops[j].Pos
+	})
+	return stops
+}
+
+// positionT maps a cell position within ctx to a gradient position in the
+// range [0.0, 1.0], according to gc.Direction.
+func (gc GradientColour) positionT(ctx renderContext) float64 {
+	var t float64
+
+	switch gc.Direction {
+	case Vertical:
+		if ctx.h > 1 {
+			t = float64(ctx.y) / float64(ctx.h-1)
+		}
+	case DiagonalTL_BR:
+		if ctx.w+ctx.h > 2 {
+			t = float64(ctx.x+ctx.y) / float64(ctx.w+ctx.h-2)
+		}
+	case DiagonalTR_BL:
+		if ctx.w+ctx.h > 2 {
+			t = float64((ctx.w-1-ctx.x)+ctx.y) / float64(ctx.w+ctx.h-2)
+		}
+	case Radial:
+		cx, cy := float64(ctx.w-1)/2, float64(ctx.h-1)/2
+		maxDist := math.Hypot(cx, cy)
+		if maxDist > 0 {
+			t = math.Hypot(float64(ctx.x)-cx, float64(ctx.y)-cy) / maxDist
+		}
+	case AngleDirection:
+		rad := gc.Angle * math.Pi / 180
+		dx, dy := math.Cos(rad), math.Sin(rad)
+		cx, cy := float64(ctx.w-1)/2, float64(ctx.h-1)/2
+		px, py := float64(ctx.x)-cx, float64(ctx.y)-cy
+		maxProj := math.Abs(cx*dx) + math.Abs(cy*dy)
+		if maxProj > 0 {
+			t = ((px*dx+py*dy)/maxProj + 1) / 2
+		}
+	default: // Horizontal
+		if ctx.w > 1 {
+			t = float64(ctx.x) / float64(ctx.w-1)
+		}
+	}
+
+	if gc.Inverse {
+		t = 1 - t
+	}
+
+	return math.Min(1, math.Max(0, t))
+}
+
+// At resolves the blended color for the cell at (x, y) within a w by h
+// block, interpolating between the two neighboring Stops in Lab space for a
+// perceptually smooth gradient. Call it once per cell to render an actual
+// gradient; see GradientColour's doc comment for why this doesn't happen
+// through color().
+func (gc GradientColour) At(r *Renderer, x, y, w, h int) termenv.Color {
+	return gc.colorAt(r, renderContext{x: x, y: y, w: w, h: h})
+}
+
+// colorAt is At's underlying implementation, taking a renderContext
+// directly.
+func (gc GradientColour) colorAt(r *Renderer, ctx renderContext) termenv.Color {
+	profile := r.ColorProfile()
+	stops := gc.sortedStops()
+
+	if len(stops) == 0 {
+		return profile.Color("")
+	}
+	if len(stops) == 1 {
+		return profile.Color(stops[0].Color)
+	}
+
+	t := gc.positionT(ctx)
+
+	for i := 0; i < len(stops)-1; i++ {
+		a, b := stops[i], stops[i+1]
+		if i < len(stops)-2 && t > b.Pos {
+			continue
+		}
+
+		local := 0.0
+		if span := b.Pos - a.Pos; span > 0 {
+			local = (t - a.Pos) / span
+		}
+		local = math.Min(1, math.Max(0, local))
+
+		ac := termenv.ConvertToRGB(profile.Color(a.Color))
+		bc := termenv.ConvertToRGB(profile.Color(b.Color))
+
+		return profile.Color(ac.BlendLab(bc, local).Hex())
+	}
+
+	return profile.Color(stops[len(stops)-1].Color)
+}
+
+func (gc GradientColour) value() string {
+	stops := gc.sortedStops()
+	if len(stops) == 0 {
+		return ""
+	}
+	return stops[0].Color
+}
+
+// color resolves the gradient's first stop, since color() has no cell
+// position to resolve a real blend against. It exists so GradientColour
+// satisfies TerminalColor; callers that want a rendered gradient must call
+// At directly for each cell instead.
+func (gc GradientColour) color(r *Renderer) termenv.Color {
+	stops := gc.sortedStops()
+	if len(stops) == 0 {
+		return r.ColorProfile().Color("")
+	}
+	return r.ColorProfile().Color(stops[0].Color)
+}
+
+func (gc GradientColour) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(gc.color(DefaultRenderer())).RGBA()
+}
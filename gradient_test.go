@@ -0,0 +1,111 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func hex(c termenv.Color) string {
+	return termenv.ConvertToRGB(c).Hex()
+}
+
+func TestGradientColourAtHorizontal(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+
+	gc := GradientColour{
+		Stops: []GradientStop{
+			{Color: "#ff0000", Pos: 0},
+			{Color: "#00ff00", Pos: 0.5},
+			{Color: "#0000ff", Pos: 1},
+		},
+		Direction: Horizontal,
+	}
+
+	start := gc.At(r, 0, 0, 5, 1)
+	mid := gc.At(r, 2, 0, 5, 1)
+	end := gc.At(r, 4, 0, 5, 1)
+
+	if got := hex(start); got != "#ff0000" {
+		t.Errorf("start: got %s, want #ff0000", got)
+	}
+	if got := hex(end); got != "#0000ff" {
+		t.Errorf("end: got %s, want #0000ff", got)
+	}
+	if got := hex(mid); got == "#ff0000" || got == "#0000ff" {
+		t.Errorf("mid: got %s, want a blended color", got)
+	}
+}
+
+func TestGradientColourAtHorizontalAndVerticalDiffer(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+
+	gc := GradientColour{
+		Stops: []GradientStop{
+			{Color: "#ff0000", Pos: 0},
+			{Color: "#0000ff", Pos: 1},
+		},
+	}
+
+	gc.Direction = Horizontal
+	h := gc.At(r, 2, 0, 5, 3)
+
+	gc.Direction = Vertical
+	v := gc.At(r, 2, 0, 5, 3)
+
+	if hex(h) == hex(v) {
+		t.Errorf("expected horizontal and vertical resolution to differ at a non-center cell, both got %s", hex(h))
+	}
+}
+
+func TestGradientColourAtInverse(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+
+	gc := GradientColour{
+		Stops: []GradientStop{
+			{Color: "#ff0000", Pos: 0},
+			{Color: "#0000ff", Pos: 1},
+		},
+		Direction: Horizontal,
+	}
+
+	start := hex(gc.At(r, 0, 0, 5, 1))
+
+	gc.Inverse = true
+	invertedEnd := hex(gc.At(r, 4, 0, 5, 1))
+
+	if start != invertedEnd {
+		t.Errorf("Inverse: got %s at the inverted end, want start color %s", invertedEnd, start)
+	}
+}
+
+func TestGradientColourColorIsFirstStop(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+
+	gc := GradientColour{
+		Stops: []GradientStop{
+			{Color: "#ff0000", Pos: 0},
+			{Color: "#0000ff", Pos: 1},
+		},
+		Direction: Vertical,
+	}
+
+	if got := hex(gc.color(r)); got != "#ff0000" {
+		t.Errorf("color(): got %s, want first stop #ff0000", got)
+	}
+}
+
+func TestGradientColourSingleStop(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+
+	gc := GradientColour{Stops: []GradientStop{{Color: "#abcdef", Pos: 0.3}}}
+
+	if got := hex(gc.At(r, 1, 1, 3, 3)); got != "#abcdef" {
+		t.Errorf("single stop: got %s, want #abcdef", got)
+	}
+}
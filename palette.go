@@ -0,0 +1,419 @@
+package lipgloss
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/muesli/termenv"
+)
+
+// Palette is a named registry of colors, letting styles reference a color
+// by name (e.g. "primary") instead of a hand-maintained hex string. A
+// Palette is safe for concurrent use.
+type Palette struct {
+	mtx    sync.RWMutex
+	colors map[string]TerminalColor
+}
+
+// NewPalette creates an empty Palette.
+func NewPalette() *Palette {
+	return &Palette{colors: make(map[string]TerminalColor)}
+}
+
+// DefaultPalette is the palette consulted by Color's "$name" syntax and by
+// Named. It comes preloaded with the built-in shade families in
+// builtinFamilies (see loadBuiltin).
+var DefaultPalette = NewPalette()
+
+func init() {
+	DefaultPalette.loadBuiltin()
+}
+
+// Set registers color under name.
+func (p *Palette) Set(name string, color TerminalColor) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.colors[name] = color
+}
+
+// Get returns the color registered under name, and whether it was found.
+func (p *Palette) Get(name string) (TerminalColor, bool) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	c, ok := p.colors[name]
+	return c, ok
+}
+
+// Named looks up name in DefaultPalette and returns its color. If name
+// isn't registered, Named returns NoColor.
+//
+// Example usage:
+//
+//	lipgloss.DefaultPalette.Set("primary", lipgloss.AdaptiveColor{Light: "#0000ff", Dark: "#000099"})
+//	style := lipgloss.NewStyle().Foreground(lipgloss.Named("primary"))
+func Named(name string) TerminalColor {
+	if c, ok := DefaultPalette.Get(name); ok {
+		return c
+	}
+	return noColor
+}
+
+// resolveNamed resolves a Color value of the form "$name" against
+// DefaultPalette. It returns ok == false for anything that isn't prefixed
+// with "$", leaving ordinary hex and ANSI values untouched.
+func resolveNamed(s string) (TerminalColor, bool) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, false
+	}
+	return DefaultPalette.Get(strings.TrimPrefix(s, "$"))
+}
+
+// PaletteFormat selects the on-disk encoding used by Palette.Load and
+// Palette.Dump.
+type PaletteFormat int
+
+// Available palette file formats.
+const (
+	FormatJSON PaletteFormat = iota
+	FormatTOML
+)
+
+// paletteEntry is the on-disk representation of a single Palette color, used
+// by Load and Dump. Dark and the ANSI256/ANSI fields are omitted for colors
+// that don't carry them: a plain Color round-trips through Light alone, an
+// AdaptiveColor adds Dark, and a CompleteColor/CompleteAdaptiveColor adds
+// the ANSI256/ANSI degradations alongside the truecolor value(s) so they
+// survive a Dump/Load round trip instead of collapsing to a bare hex.
+type paletteEntry struct {
+	Light        string `json:"light"`
+	Dark         string `json:"dark,omitempty"`
+	LightANSI256 string `json:"light_ansi256,omitempty"`
+	LightANSI    string `json:"light_ansi,omitempty"`
+	DarkANSI256  string `json:"dark_ansi256,omitempty"`
+	DarkANSI     string `json:"dark_ansi,omitempty"`
+}
+
+// hasANSI reports whether e carries precomputed ANSI256/ANSI degradations,
+// i.e. it came from a CompleteColor or CompleteAdaptiveColor.
+func (e paletteEntry) hasANSI() bool {
+	return e.LightANSI256 != "" || e.LightANSI != "" || e.DarkANSI256 != "" || e.DarkANSI != ""
+}
+
+// color converts e back into the most specific TerminalColor it describes.
+func (e paletteEntry) color() TerminalColor {
+	switch {
+	case e.hasANSI() && e.Dark != "":
+		return CompleteAdaptiveColor{
+			Light: CompleteColor{TrueColor: e.Light, ANSI256: e.LightANSI256, ANSI: e.LightANSI},
+			Dark:  CompleteColor{TrueColor: e.Dark, ANSI256: e.DarkANSI256, ANSI: e.DarkANSI},
+		}
+	case e.hasANSI():
+		return CompleteColor{TrueColor: e.Light, ANSI256: e.LightANSI256, ANSI: e.LightANSI}
+	case e.Dark != "":
+		return AdaptiveColor{Light: e.Light, Dark: e.Dark}
+	default:
+		return Color(e.Light)
+	}
+}
+
+// paletteEntryFor converts c into its on-disk paletteEntry, preserving
+// CompleteColor/CompleteAdaptiveColor's ANSI256/ANSI degradations so Dump
+// followed by Load reproduces the original color.
+func paletteEntryFor(c TerminalColor) paletteEntry {
+	switch v := c.(type) {
+	case CompleteAdaptiveColor:
+		return paletteEntry{
+			Light:        v.Light.TrueColor,
+			LightANSI256: v.Light.ANSI256,
+			LightANSI:    v.Light.ANSI,
+			Dark:         v.Dark.TrueColor,
+			DarkANSI256:  v.Dark.ANSI256,
+			DarkANSI:     v.Dark.ANSI,
+		}
+	case CompleteColor:
+		return paletteEntry{Light: v.TrueColor, LightANSI256: v.ANSI256, LightANSI: v.ANSI}
+	case AdaptiveColor:
+		return paletteEntry{Light: v.Light, Dark: v.Dark}
+	case Color:
+		return paletteEntry{Light: string(v)}
+	default:
+		return paletteEntry{Light: c.value()}
+	}
+}
+
+// Load reads a theme file in the given format from r and registers its
+// entries, replacing any existing entries of the same name.
+func (p *Palette) Load(r io.Reader, format PaletteFormat) error {
+	var entries map[string]paletteEntry
+	switch format {
+	case FormatTOML:
+		e, err := decodePaletteTOML(r)
+		if err != nil {
+			return err
+		}
+		entries = e
+	default:
+		if err := json.NewDecoder(r).Decode(&entries); err != nil {
+			return fmt.Errorf("lipgloss: decode palette: %w", err)
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for name, e := range entries {
+		p.colors[name] = e.color()
+	}
+	return nil
+}
+
+// Dump writes the palette to w in the given format, keyed by name, so it
+// can be edited and reloaded with Load.
+func (p *Palette) Dump(w io.Writer, format PaletteFormat) error {
+	p.mtx.RLock()
+	entries := make(map[string]paletteEntry, len(p.colors))
+	for name, c := range p.colors {
+		entries[name] = paletteEntryFor(c)
+	}
+	p.mtx.RUnlock()
+
+	if format == FormatTOML {
+		return encodePaletteTOML(w, entries)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// encodePaletteTOML renders entries as a minimal TOML document: one table
+// per name, in alphabetical order of name for a stable diff, with each
+// populated field as a bare key set to a quoted string value.
+func encodePaletteTOML(w io.Writer, entries map[string]paletteEntry) error {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		e := entries[name]
+		if _, err := fmt.Fprintf(w, "[%s]\n", tomlQuote(name)); err != nil {
+			return err
+		}
+		for _, f := range []struct {
+			key, value string
+		}{
+			{"light", e.Light},
+			{"dark", e.Dark},
+			{"light_ansi256", e.LightANSI256},
+			{"light_ansi", e.LightANSI},
+			{"dark_ansi256", e.DarkANSI256},
+			{"dark_ansi", e.DarkANSI},
+		} {
+			if f.value == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s = %s\n", f.key, tomlQuote(f.value)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tomlKeys lists the paletteEntry fields decodePaletteTOML accepts, keyed by
+// their TOML field name.
+var tomlKeys = map[string]func(e *paletteEntry, v string){
+	"light":         func(e *paletteEntry, v string) { e.Light = v },
+	"dark":          func(e *paletteEntry, v string) { e.Dark = v },
+	"light_ansi256": func(e *paletteEntry, v string) { e.LightANSI256 = v },
+	"light_ansi":    func(e *paletteEntry, v string) { e.LightANSI = v },
+	"dark_ansi256":  func(e *paletteEntry, v string) { e.DarkANSI256 = v },
+	"dark_ansi":     func(e *paletteEntry, v string) { e.DarkANSI = v },
+}
+
+// decodePaletteTOML parses the minimal TOML subset encodePaletteTOML
+// produces: `[name]` table headers followed by `key = "value"` lines.
+func decodePaletteTOML(r io.Reader) (map[string]paletteEntry, error) {
+	entries := make(map[string]paletteEntry)
+
+	var name string
+	var cur paletteEntry
+	var inTable bool
+	flush := func() {
+		if inTable {
+			entries[name] = cur
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			flush()
+			name = tomlUnquote(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			cur = paletteEntry{}
+			inTable = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("lipgloss: decode palette: invalid line %q", line)
+		}
+		key = strings.TrimSpace(key)
+		set, ok := tomlKeys[key]
+		if !ok {
+			return nil, fmt.Errorf("lipgloss: decode palette: unknown key %q", key)
+		}
+		set(&cur, tomlUnquote(strings.TrimSpace(value)))
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("lipgloss: decode palette: %w", err)
+	}
+	return entries, nil
+}
+
+// tomlQuote renders s as a quoted TOML string, escaping backslashes and
+// double quotes.
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlUnquote strips a quoted TOML string's surrounding quotes and
+// backslash escapes. Unquoted input is returned unchanged.
+func tomlUnquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// completeColorFromHex precomputes a CompleteColor's ANSI256 and ANSI
+// degradations for a truecolor hex value, so built-in palette colors don't
+// pay for color-space conversion on every render.
+func completeColorFromHex(hex string) CompleteColor {
+	return CompleteColor{
+		TrueColor: hex,
+		ANSI256:   nearestIndex(hex, termenv.ANSI256),
+		ANSI:      nearestIndex(hex, termenv.ANSI),
+	}
+}
+
+// nearestIndex returns the string form of the nearest color to hex in
+// profile, as expected by CompleteColor's ANSI256/ANSI fields.
+func nearestIndex(hex string, profile termenv.Profile) string {
+	switch v := profile.Color(hex).(type) {
+	case termenv.ANSI256Color:
+		return strconv.Itoa(int(v))
+	case termenv.ANSIColor:
+		return strconv.Itoa(int(v))
+	default:
+		return hex
+	}
+}
+
+// builtinSwatch returns a CompleteAdaptiveColor for a shade at lightHex,
+// with its dark-mode variant at darkHex. Both are precomputed with their
+// ANSI256/ANSI degradations once, at load time.
+func builtinSwatch(lightHex, darkHex string) CompleteAdaptiveColor {
+	return CompleteAdaptiveColor{
+		Light: completeColorFromHex(lightHex),
+		Dark:  completeColorFromHex(darkHex),
+	}
+}
+
+// paletteScaleSteps lists the shade steps a builtin Tailwind-style scale
+// family is defined across, from lightest to darkest.
+var paletteScaleSteps = []string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900", "950"}
+
+// mirrorScaleStep returns step's counterpart on the opposite end of
+// paletteScaleSteps (e.g. "50" <-> "950", "500" <-> "500"). It's how a
+// builtin swatch's dark-mode variant is picked: the scale step that reads on
+// a dark background the way step reads on a light one.
+func mirrorScaleStep(step string) string {
+	for i, s := range paletteScaleSteps {
+		if s == step {
+			return paletteScaleSteps[len(paletteScaleSteps)-1-i]
+		}
+	}
+	return step
+}
+
+// builtinFamilies holds the Tailwind-like shade families shipped with
+// lipgloss, keyed by family name and then by step (see paletteScaleSteps).
+// This is a modest starter set (slate, sky, red, emerald), not the full
+// Tailwind palette; applications that want more scales can register them
+// the same way, via Palette.Set or a loaded theme file.
+var builtinFamilies = map[string]map[string]string{
+	"slate": {
+		"50": "#f8fafc", "100": "#f1f5f9", "200": "#e2e8f0", "300": "#cbd5e1",
+		"400": "#94a3b8", "500": "#64748b", "600": "#475569", "700": "#334155",
+		"800": "#1e293b", "900": "#0f172a", "950": "#020617",
+	},
+	"sky": {
+		"50": "#f0f9ff", "100": "#e0f2fe", "200": "#bae6fd", "300": "#7dd3fc",
+		"400": "#38bdf8", "500": "#0ea5e9", "600": "#0284c7", "700": "#0369a1",
+		"800": "#075985", "900": "#0c4a6e", "950": "#082f49",
+	},
+	"red": {
+		"50": "#fef2f2", "100": "#fee2e2", "200": "#fecaca", "300": "#fca5a5",
+		"400": "#f87171", "500": "#ef4444", "600": "#dc2626", "700": "#b91c1c",
+		"800": "#991b1b", "900": "#7f1d1d", "950": "#450a0a",
+	},
+	"emerald": {
+		"50": "#ecfdf5", "100": "#d1fae5", "200": "#a7f3d0", "300": "#6ee7b7",
+		"400": "#34d399", "500": "#10b981", "600": "#059669", "700": "#047857",
+		"800": "#065f46", "900": "#064e3b", "950": "#022c22",
+	},
+}
+
+// loadBuiltin registers the built-in Tailwind-like scales into p, under
+// names of the form "<family>-<step>" (e.g. "slate-500").
+func (p *Palette) loadBuiltin() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for family, shades := range builtinFamilies {
+		for _, step := range paletteScaleSteps {
+			light, ok := shades[step]
+			if !ok {
+				continue
+			}
+			dark := shades[mirrorScaleStep(step)]
+			p.colors[family+"-"+step] = builtinSwatch(light, dark)
+		}
+	}
+}
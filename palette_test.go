@@ -0,0 +1,115 @@
+package lipgloss
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPaletteJSONRoundTrip(t *testing.T) {
+	p := NewPalette()
+	p.Set("primary", Color("#0000ff"))
+	p.Set("accent", AdaptiveColor{Light: "#0000ff", Dark: "#000099"})
+	p.Set("brand", CompleteAdaptiveColor{
+		Light: CompleteColor{TrueColor: "#f8fafc", ANSI256: "255", ANSI: "15"},
+		Dark:  CompleteColor{TrueColor: "#020617", ANSI256: "16", ANSI: "0"},
+	})
+
+	var buf bytes.Buffer
+	if err := p.Dump(&buf, FormatJSON); err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+
+	loaded := NewPalette()
+	if err := loaded.Load(&buf, FormatJSON); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	assertPaletteRoundTrip(t, p, loaded)
+}
+
+func TestPaletteTOMLRoundTrip(t *testing.T) {
+	p := NewPalette()
+	p.Set("primary", Color("#0000ff"))
+	p.Set("accent", AdaptiveColor{Light: "#0000ff", Dark: "#000099"})
+	p.Set("brand", CompleteAdaptiveColor{
+		Light: CompleteColor{TrueColor: "#f8fafc", ANSI256: "255", ANSI: "15"},
+		Dark:  CompleteColor{TrueColor: "#020617", ANSI256: "16", ANSI: "0"},
+	})
+
+	var buf bytes.Buffer
+	if err := p.Dump(&buf, FormatTOML); err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+
+	loaded := NewPalette()
+	if err := loaded.Load(&buf, FormatTOML); err != nil {
+		t.Fatalf("Load: %v\n%s", err, buf.String())
+	}
+
+	assertPaletteRoundTrip(t, p, loaded)
+}
+
+func assertPaletteRoundTrip(t *testing.T, want, got *Palette) {
+	t.Helper()
+
+	for _, name := range []string{"primary", "accent", "brand"} {
+		wantColor, ok := want.Get(name)
+		if !ok {
+			t.Fatalf("missing %q in original palette", name)
+		}
+		gotColor, ok := got.Get(name)
+		if !ok {
+			t.Fatalf("%q missing after round trip", name)
+		}
+		if gotColor != wantColor {
+			t.Errorf("%q: got %#v, want %#v", name, gotColor, wantColor)
+		}
+	}
+}
+
+func TestBuiltinPaletteHasMirroredDarkVariants(t *testing.T) {
+	light, ok := DefaultPalette.Get("slate-50")
+	if !ok {
+		t.Fatal("slate-50 not registered")
+	}
+	dark, ok := DefaultPalette.Get("slate-950")
+	if !ok {
+		t.Fatal("slate-950 not registered")
+	}
+
+	lightCAC, ok := light.(CompleteAdaptiveColor)
+	if !ok {
+		t.Fatalf("slate-50 is a %T, want CompleteAdaptiveColor", light)
+	}
+	darkCAC, ok := dark.(CompleteAdaptiveColor)
+	if !ok {
+		t.Fatalf("slate-950 is a %T, want CompleteAdaptiveColor", dark)
+	}
+
+	if lightCAC.Light == lightCAC.Dark {
+		t.Error("slate-50: Light and Dark variants are identical, expected a mirrored dark shade")
+	}
+	if lightCAC.Dark != darkCAC.Light {
+		t.Errorf("slate-50's dark variant (%+v) should mirror slate-950's light variant (%+v)", lightCAC.Dark, darkCAC.Light)
+	}
+}
+
+func TestNamedResolvesAgainstDefaultPalette(t *testing.T) {
+	DefaultPalette.Set("test-named", Color("#abcdef"))
+
+	if got := Named("test-named").value(); got != "#abcdef" {
+		t.Errorf("got %s, want #abcdef", got)
+	}
+
+	if got := Named("test-does-not-exist"); got != noColor {
+		t.Errorf("got %#v, want NoColor for an unregistered name", got)
+	}
+}
+
+func TestColorResolvesDollarReference(t *testing.T) {
+	DefaultPalette.Set("test-dollar", Color("#123456"))
+
+	if got := Color("$test-dollar").value(); got != "#123456" {
+		t.Errorf("got %s, want #123456", got)
+	}
+}
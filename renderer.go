@@ -0,0 +1,153 @@
+package lipgloss
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/muesli/termenv"
+)
+
+// Renderer is a lipgloss render engine targeting a single output. Unlike the
+// package-level functions, which share one global color profile and
+// background detection result, a Renderer keeps that state to itself. This
+// makes it possible to style output bound for two different destinations
+// (a TTY and a log file, a local terminal and an SSH session, two tests
+// asserting against different profiles) within the same process.
+//
+// Use DefaultRenderer to get the renderer the package-level functions use,
+// or NewRenderer to create one targeting a specific io.Writer.
+type Renderer struct {
+	output *termenv.Output
+
+	mtx sync.RWMutex
+
+	colorProfile         termenv.Profile
+	explicitColorProfile bool
+	colorProfileDetected bool
+
+	hasDarkBackground       bool
+	explicitBackgroundColor bool
+	backgroundDetected      bool
+}
+
+var (
+	defaultRenderer     *Renderer
+	defaultRendererOnce sync.Once
+)
+
+// DefaultRenderer returns the default renderer, which targets os.Stdout and
+// detects its capabilities the first time it's asked to.
+func DefaultRenderer() *Renderer {
+	defaultRendererOnce.Do(func() {
+		defaultRenderer = NewRenderer(os.Stdout)
+	})
+	return defaultRenderer
+}
+
+// NewRenderer creates a new Renderer targeting the given output. Detection
+// of the output's color profile and background color is deferred until it's
+// first needed.
+func NewRenderer(w io.Writer) *Renderer {
+	return &Renderer{
+		output: termenv.NewOutput(w),
+	}
+}
+
+// Output returns the underlying termenv.Output for this renderer.
+func (r *Renderer) Output() *termenv.Output {
+	return r.output
+}
+
+// ColorProfile returns the detected termenv color profile for this
+// renderer. It will perform the actual check only once, until invalidated
+// with InvalidateColorProfile.
+func (r *Renderer) ColorProfile() termenv.Profile {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if !r.explicitColorProfile && !r.colorProfileDetected {
+		r.colorProfile = r.output.ColorProfile()
+		r.colorProfileDetected = true
+	}
+	return r.colorProfile
+}
+
+// InvalidateColorProfile clears this renderer's cached color profile, so
+// the next call to ColorProfile() re-detects it. It has no effect if the
+// profile was set explicitly via SetColorProfile.
+func (r *Renderer) InvalidateColorProfile() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.colorProfileDetected = false
+}
+
+// SetColorProfile sets the color profile for this renderer. This function
+// exists mostly for testing purposes so that you can assure you're testing
+// against a specific profile.
+//
+// Outside of testing you likely won't want to use this function as
+// ColorProfile() will detect and cache the output's color capabilities and
+// choose the best available profile.
+//
+// This function is thread-safe.
+func (r *Renderer) SetColorProfile(p termenv.Profile) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.colorProfile = p
+	r.explicitColorProfile = true
+}
+
+// HasDarkBackground returns whether or not this renderer's output has a
+// dark background. It will perform the actual check only once, until
+// invalidated with InvalidateBackgroundCache.
+func (r *Renderer) HasDarkBackground() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if !r.explicitBackgroundColor && !r.backgroundDetected {
+		r.hasDarkBackground = r.output.HasDarkBackground()
+		r.backgroundDetected = true
+	}
+
+	return r.hasDarkBackground
+}
+
+// InvalidateBackgroundCache clears this renderer's cached background color
+// detection, so the next call to HasDarkBackground() re-queries it (via
+// OSC 11). It has no effect if the background was set explicitly via
+// SetHasDarkBackground. WatchBackground uses this to force re-detection on
+// each poll.
+func (r *Renderer) InvalidateBackgroundCache() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.backgroundDetected = false
+}
+
+// SetHasDarkBackground sets the background color detection value for this
+// renderer. This function exists mostly for testing purposes so that you
+// can assure you're testing against a specific background color setting.
+//
+// Outside of testing you likely won't want to use this function as
+// HasDarkBackground() will detect and cache the output's current background
+// color setting.
+//
+// This function is thread-safe.
+func (r *Renderer) SetHasDarkBackground(b bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.hasDarkBackground = b
+	r.explicitBackgroundColor = true
+}
+
+// Known scope gap: this package has no Style type yet, so there is nowhere
+// to put Style.Renderer(r) or a (*Renderer).Render(Style, string) method
+// that resolves a style's colors against a specific Renderer. Every
+// TerminalColor's color(r *Renderer) method already accepts a Renderer, so
+// once Style exists, wiring it through is a matter of Style carrying an
+// optional *Renderer and passing it (or DefaultRenderer()) to color() at
+// render time - not a redesign of this file.
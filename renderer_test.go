@@ -0,0 +1,50 @@
+package lipgloss
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestRendererColorProfileCachesUntilInvalidated(t *testing.T) {
+	r := NewRenderer(&bytes.Buffer{})
+	r.SetColorProfile(termenv.ANSI256)
+
+	if got := r.ColorProfile(); got != termenv.ANSI256 {
+		t.Fatalf("got %v, want ANSI256", got)
+	}
+
+	// SetColorProfile marks the profile explicit, so InvalidateColorProfile
+	// should have no effect on it.
+	r.InvalidateColorProfile()
+	if got := r.ColorProfile(); got != termenv.ANSI256 {
+		t.Fatalf("after invalidate: got %v, want ANSI256 (explicit profile should stick)", got)
+	}
+}
+
+func TestRendererHasDarkBackgroundExplicit(t *testing.T) {
+	r := NewRenderer(&bytes.Buffer{})
+	r.SetHasDarkBackground(true)
+
+	if !r.HasDarkBackground() {
+		t.Fatal("expected explicit dark background to be true")
+	}
+
+	r.SetHasDarkBackground(false)
+	if r.HasDarkBackground() {
+		t.Fatal("expected explicit dark background to be false after SetHasDarkBackground(false)")
+	}
+}
+
+func TestRenderersAreIndependent(t *testing.T) {
+	a := NewRenderer(&bytes.Buffer{})
+	b := NewRenderer(&bytes.Buffer{})
+
+	a.SetColorProfile(termenv.TrueColor)
+	b.SetColorProfile(termenv.Ascii)
+
+	if a.ColorProfile() == b.ColorProfile() {
+		t.Fatal("expected independently configured renderers to have different profiles")
+	}
+}